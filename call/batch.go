@@ -0,0 +1,184 @@
+package call
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/depocket/multicall-go/core"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultMaxBatchSize is how many calls CallWithContext packs into a single
+// eth_call round-trip before splitting into another sub-batch. Public RPC
+// endpoints tend to cap eth_call at roughly 30M gas or a 10MB response, both
+// of which a batch of thousands of ERC-20 balance reads can exceed.
+const DefaultMaxBatchSize = 500
+
+// defaultMaxWorkers bounds how many sub-batches run concurrently so a large
+// split doesn't open hundreds of simultaneous connections to the RPC node.
+const defaultMaxWorkers = 4
+
+// gasOverheadPerCall and gasPerCallDataByte are a rough calldata-based proxy
+// for a sub-call's gas cost, used only to size batches under WithMaxGasPerBatch.
+// They are not a substitute for eth_estimateGas and deliberately err on the
+// side of smaller batches.
+const gasOverheadPerCall = 21000
+const gasPerCallDataByte = 16
+
+type callOptions struct {
+	maxBatchSize int
+	maxGasBudget uint64
+	maxWorkers   int
+}
+
+// CallOption configures how CallWithContext splits and schedules sub-batches.
+type CallOption func(*callOptions)
+
+// WithMaxBatchSize overrides DefaultMaxBatchSize.
+func WithMaxBatchSize(size int) CallOption {
+	return func(o *callOptions) { o.maxBatchSize = size }
+}
+
+// WithMaxGasPerBatch sizes sub-batches by an estimated gas budget instead of
+// a fixed call count, grouping calls until the next one would push the
+// running estimate over budget.
+func WithMaxGasPerBatch(gas uint64) CallOption {
+	return func(o *callOptions) { o.maxGasBudget = gas }
+}
+
+// WithMaxWorkers bounds how many sub-batches execute concurrently.
+func WithMaxWorkers(workers int) CallOption {
+	return func(o *callOptions) { o.maxWorkers = workers }
+}
+
+func newCallOptions(opts []CallOption) callOptions {
+	options := callOptions{maxBatchSize: DefaultMaxBatchSize, maxWorkers: defaultMaxWorkers}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.maxWorkers <= 0 {
+		// errgroup.SetLimit(0) blocks every Go() forever since nothing ever
+		// frees the semaphore; a caller passing WithMaxWorkers(0) wants
+		// "no extra workers", not a permanent hang.
+		options.maxWorkers = 1
+	}
+	return options
+}
+
+// CallWithContext behaves like Call, but accepts a context for cancellation
+// and splits large batches into sub-batches (DefaultMaxBatchSize calls, or
+// a gas budget via WithMaxGasPerBatch) executed concurrently across a
+// bounded worker pool. Every sub-batch is pinned to the same block number:
+// when the caller doesn't pin one explicitly, CallWithContext resolves the
+// current head once up front so an advancing chain tip between sub-batches
+// can't produce an inconsistent result. As with Call, AllowFailure(true)
+// runs every sub-batch through the tolerant aggregate3/tryAggregate path
+// and leaves a reverted call's entry nil instead of failing the batch.
+func (a *contract) CallWithContext(ctx context.Context, blockNumber *big.Int, opts ...CallOption) (*big.Int, map[string][]interface{}, error) {
+	if a.err != nil {
+		err := a.err
+		a.ClearCall()
+		return nil, nil, err
+	}
+
+	options := newCallOptions(opts)
+	batches := chunkCalls(a.calls, options)
+
+	pinnedBlock := blockNumber
+	if pinnedBlock == nil {
+		header, err := a.ethClient.HeaderByNumber(ctx, nil)
+		if err != nil {
+			a.ClearCall()
+			return nil, nil, err
+		}
+		pinnedBlock = header.Number
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(options.maxWorkers)
+
+	batchResults := make([]map[string]core.CallResult, len(batches))
+	for i, batch := range batches {
+		i, batch := i, batch
+		group.Go(func() error {
+			var (
+				results map[string]core.CallResult
+				err     error
+			)
+			if a.allowFailure {
+				_, results, err = a.multiCaller.ExecuteAllowFailureContext(groupCtx, batch, pinnedBlock)
+			} else {
+				_, results, err = a.multiCaller.ExecuteContext(groupCtx, batch, pinnedBlock)
+			}
+			if err != nil {
+				return err
+			}
+			batchResults[i] = results
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		a.ClearCall()
+		return nil, nil, err
+	}
+
+	res := make(map[string][]interface{})
+	var unpackErr error
+	for i, batch := range batches {
+		for _, c := range batch {
+			result := batchResults[i][c.Name]
+			if a.allowFailure && !result.Success {
+				res[c.Name] = nil
+				continue
+			}
+			decoded, decodeErr := a.contractAbi.Unpack(c.Method, result.ReturnData)
+			if decodeErr != nil && unpackErr == nil {
+				unpackErr = decodeErr
+			}
+			res[c.Name] = decoded
+		}
+	}
+	a.ClearCall()
+	return pinnedBlock, res, unpackErr
+}
+
+func chunkCalls(calls []core.Call, options callOptions) [][]core.Call {
+	if options.maxGasBudget > 0 {
+		return chunkCallsByGas(calls, options.maxGasBudget)
+	}
+	return chunkCallsBySize(calls, options.maxBatchSize)
+}
+
+func chunkCallsBySize(calls []core.Call, size int) [][]core.Call {
+	if size <= 0 {
+		size = DefaultMaxBatchSize
+	}
+	batches := make([][]core.Call, 0, (len(calls)+size-1)/size)
+	for size < len(calls) {
+		calls, batches = calls[size:], append(batches, calls[0:size:size])
+	}
+	if len(calls) > 0 {
+		batches = append(batches, calls)
+	}
+	return batches
+}
+
+func chunkCallsByGas(calls []core.Call, maxGas uint64) [][]core.Call {
+	batches := make([][]core.Call, 0)
+	var current []core.Call
+	var currentGas uint64
+	for _, c := range calls {
+		callGas := gasOverheadPerCall + uint64(len(c.CallData))*gasPerCallDataByte
+		if len(current) > 0 && currentGas+callGas > maxGas {
+			batches = append(batches, current)
+			current = nil
+			currentGas = 0
+		}
+		current = append(current, c)
+		currentGas += callGas
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
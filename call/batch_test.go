@@ -0,0 +1,105 @@
+package call
+
+import (
+	"testing"
+
+	"github.com/depocket/multicall-go/core"
+)
+
+func makeCalls(n int, callDataLen int) []core.Call {
+	calls := make([]core.Call, n)
+	for i := range calls {
+		calls[i] = core.Call{
+			Name:     string(rune('a' + i)),
+			CallData: make([]byte, callDataLen),
+		}
+	}
+	return calls
+}
+
+func TestChunkCallsBySize(t *testing.T) {
+	tests := []struct {
+		name       string
+		numCalls   int
+		size       int
+		wantChunks []int
+	}{
+		{"even split", 10, 5, []int{5, 5}},
+		{"remainder", 11, 5, []int{5, 5, 1}},
+		{"single batch smaller than size", 3, 5, []int{3}},
+		{"empty", 0, 5, nil},
+		{"non-positive size falls back to default", 1, 0, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := chunkCallsBySize(makeCalls(tt.numCalls, 0), tt.size)
+			if len(batches) != len(tt.wantChunks) {
+				t.Fatalf("got %d batches, want %d", len(batches), len(tt.wantChunks))
+			}
+			total := 0
+			for i, batch := range batches {
+				if len(batch) != tt.wantChunks[i] {
+					t.Errorf("batch %d has %d calls, want %d", i, len(batch), tt.wantChunks[i])
+				}
+				total += len(batch)
+			}
+			if total != tt.numCalls {
+				t.Errorf("batches contain %d calls total, want %d", total, tt.numCalls)
+			}
+		})
+	}
+}
+
+func TestChunkCallsByGas(t *testing.T) {
+	// Each call here costs gasOverheadPerCall + 4*gasPerCallDataByte.
+	perCallGas := gasOverheadPerCall + uint64(4)*gasPerCallDataByte
+
+	tests := []struct {
+		name       string
+		numCalls   int
+		maxGas     uint64
+		wantChunks []int
+	}{
+		{"one call per batch", 3, perCallGas, []int{1, 1, 1}},
+		{"two calls per batch", 4, perCallGas * 2, []int{2, 2}},
+		{"oversized budget keeps a single batch", 5, perCallGas * 10, []int{5}},
+		{"no calls", 0, perCallGas, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := chunkCallsByGas(makeCalls(tt.numCalls, 4), tt.maxGas)
+			if len(batches) != len(tt.wantChunks) {
+				t.Fatalf("got %d batches, want %d", len(batches), len(tt.wantChunks))
+			}
+			total := 0
+			for i, batch := range batches {
+				if len(batch) != tt.wantChunks[i] {
+					t.Errorf("batch %d has %d calls, want %d", i, len(batch), tt.wantChunks[i])
+				}
+				total += len(batch)
+			}
+			if total != tt.numCalls {
+				t.Errorf("batches contain %d calls total, want %d", total, tt.numCalls)
+			}
+		})
+	}
+}
+
+func TestNewCallOptionsClampsMaxWorkers(t *testing.T) {
+	options := newCallOptions([]CallOption{WithMaxWorkers(0)})
+	if options.maxWorkers != 1 {
+		t.Errorf("maxWorkers = %d, want 1", options.maxWorkers)
+	}
+
+	options = newCallOptions([]CallOption{WithMaxWorkers(-5)})
+	if options.maxWorkers != 1 {
+		t.Errorf("maxWorkers = %d, want 1", options.maxWorkers)
+	}
+
+	options = newCallOptions([]CallOption{WithMaxWorkers(8)})
+	if options.maxWorkers != 8 {
+		t.Errorf("maxWorkers = %d, want 8", options.maxWorkers)
+	}
+}
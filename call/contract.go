@@ -1,12 +1,15 @@
 package call
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/depocket/multicall-go/core"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"math/big"
+	"os"
 	"strings"
 )
 
@@ -14,6 +17,7 @@ type Argument struct {
 	Name         string `json:"name"`
 	Type         string `json:"type"`
 	InternalType string `json:"internalType"`
+	Indexed      bool   `json:"indexed,omitempty"`
 }
 
 type Method struct {
@@ -28,17 +32,40 @@ type ContractBuilder interface {
 	WithClient(ethClient *ethclient.Client) ContractBuilder
 	AtAddress(contractAddress string) ContractBuilder
 	AddMethod(signature string) ContractBuilder
+	AddError(signature string) ContractBuilder
+	WithABI(jsonABI string) ContractBuilder
+	WithABIFile(filePath string) ContractBuilder
+	AllowFailure(allow bool) ContractBuilder
+	AddCall(callName string, contractAddress string, method string, args ...interface{}) ContractBuilder
+	Call(blockNumber *big.Int) (*big.Int, map[string][]interface{}, error)
+	CallAllowFailure(blockNumber *big.Int) (*big.Int, []CallResult, error)
+	CallWithContext(ctx context.Context, blockNumber *big.Int, opts ...CallOption) (*big.Int, map[string][]interface{}, error)
+	ClearCall()
 	Abi() abi.ABI
-	Build() *contract
+	Build() (ContractBuilder, error)
+}
+
+// CallResult is the outcome of one call made with allowFailure enabled.
+// DecodedValues is nil when Success is false, since there is no return
+// data to unpack.
+type CallResult struct {
+	Name          string
+	Success       bool
+	ReturnData    []byte
+	DecodedValues []interface{}
+	RevertReason  string
+	Err           error
 }
 
 type contract struct {
-	ethClient   *ethclient.Client
-	contractAbi abi.ABI
-	rawMethods  map[string]string
-	methods     []Method
-	calls       []core.Call
-	multiCaller *core.MultiCaller
+	ethClient    *ethclient.Client
+	contractAbi  abi.ABI
+	rawMethods   map[string]string
+	methods      []Method
+	calls        []core.Call
+	multiCaller  *core.MultiCaller
+	allowFailure bool
+	err          error
 }
 
 func NewContractBuilder() ContractBuilder {
@@ -54,23 +81,34 @@ func (a *contract) WithClient(ethClient *ethclient.Client) ContractBuilder {
 	return a
 }
 
-func (a *contract) Build() *contract {
-	return a
+// Build surfaces any error accumulated by a prior builder call - AtAddress,
+// AddMethod, AddError, WithABI or WithABIFile - instead of panicking deep
+// inside whichever one first failed.
+func (a *contract) Build() (ContractBuilder, error) {
+	return a, a.err
 }
 
 func (a *contract) AtAddress(address string) ContractBuilder {
+	if a.err != nil {
+		return a
+	}
 	caller, err := core.NewMultiCaller(a.ethClient, common.HexToAddress(address))
 	if err != nil {
-		panic(err)
+		a.err = err
+		return a
 	}
 	a.multiCaller = caller
 	return a
 }
 
-func (a *contract) AddCall(callName string, contractAddress string, method string, args ...interface{}) *contract {
+func (a *contract) AddCall(callName string, contractAddress string, method string, args ...interface{}) ContractBuilder {
+	if a.err != nil {
+		return a
+	}
 	callData, err := a.contractAbi.Pack(method, args...)
 	if err != nil {
-		panic(err)
+		a.err = err
+		return a
 	}
 	a.calls = append(a.calls, core.Call{
 		Method:   method,
@@ -82,20 +120,107 @@ func (a *contract) AddCall(callName string, contractAddress string, method strin
 }
 
 func (a *contract) AddMethod(signature string) ContractBuilder {
+	if a.err != nil {
+		return a
+	}
 	existCall, ok := a.rawMethods[strings.ToLower(signature)]
 	if ok {
-		panic("Caller named " + existCall + " is exist on ABI")
+		a.err = fmt.Errorf("call: method %q is already registered on this ABI", existCall)
+		return a
+	}
+	method, err := parseNewMethod(signature)
+	if err != nil {
+		a.err = err
+		return a
 	}
 	a.rawMethods[strings.ToLower(signature)] = signature
-	a.methods = append(a.methods, parseNewMethod(signature))
+	a.methods = append(a.methods, method)
+	newAbi, err := repackAbi(a.methods)
+	if err != nil {
+		a.err = err
+		return a
+	}
+	a.contractAbi = newAbi
+	return a
+}
+
+// AddError registers a human-readable custom error signature, e.g.
+// "error InsufficientBalance(uint256 available, uint256 required)", so
+// CallAllowFailure can decode it out of a failed sub-call's revert data.
+func (a *contract) AddError(signature string) ContractBuilder {
+	if a.err != nil {
+		return a
+	}
+	method, err := parseNewError(signature)
+	if err != nil {
+		a.err = err
+		return a
+	}
+	a.methods = append(a.methods, method)
+	newAbi, err := repackAbi(a.methods)
+	if err != nil {
+		a.err = err
+		return a
+	}
+	a.contractAbi = newAbi
+	return a
+}
+
+// WithABI loads every function declared in a standard Solidity JSON ABI,
+// going through abi.JSON directly instead of the hand-rolled signature
+// parser. Unlike AddMethod, this understands nested tuples, dynamic arrays
+// of structs and overloaded function names.
+func (a *contract) WithABI(jsonABI string) ContractBuilder {
+	if a.err != nil {
+		return a
+	}
+	parsedAbi, err := abi.JSON(strings.NewReader(jsonABI))
+	if err != nil {
+		a.err = err
+		return a
+	}
+	for _, method := range parsedAbi.Methods {
+		existCall, ok := a.rawMethods[strings.ToLower(method.Sig)]
+		if ok {
+			a.err = fmt.Errorf("call: method %q is already registered on this ABI", existCall)
+			return a
+		}
+		a.rawMethods[strings.ToLower(method.Sig)] = method.Sig
+		a.methods = append(a.methods, methodFromABI(method))
+	}
+	for _, customErr := range parsedAbi.Errors {
+		a.methods = append(a.methods, errorFromABI(customErr))
+	}
 	newAbi, err := repackAbi(a.methods)
 	if err != nil {
-		panic(err)
+		a.err = err
+		return a
 	}
 	a.contractAbi = newAbi
+	return a
+}
+
+// WithABIFile reads a Solidity JSON ABI from disk and loads it the same way
+// as WithABI.
+func (a *contract) WithABIFile(filePath string) ContractBuilder {
+	if a.err != nil {
+		return a
+	}
+	content, err := os.ReadFile(filePath)
 	if err != nil {
-		panic(err)
+		a.err = err
+		return a
 	}
+	return a.WithABI(string(content))
+}
+
+// AllowFailure switches Call into tolerant mode: instead of failing the
+// whole batch on any revert, a reverted call's entry in the result map is
+// simply nil while the rest of the batch still decodes normally. Use
+// CallAllowFailure directly when per-call success/revert-reason detail is
+// needed too.
+func (a *contract) AllowFailure(allow bool) ContractBuilder {
+	a.allowFailure = allow
 	return a
 }
 
@@ -104,23 +229,107 @@ func (a *contract) Abi() abi.ABI {
 }
 
 func (a *contract) Call(blockNumber *big.Int) (*big.Int, map[string][]interface{}, error) {
-	res := make(map[string][]interface{})
+	if a.err != nil {
+		err := a.err
+		a.ClearCall()
+		return nil, nil, err
+	}
+	if a.allowFailure {
+		return a.callAllowFailure(blockNumber)
+	}
+
 	blockNumber, results, err := a.multiCaller.Execute(a.calls, blockNumber)
+	if err != nil {
+		a.ClearCall()
+		return blockNumber, nil, err
+	}
+
+	res := make(map[string][]interface{})
+	var unpackErr error
 	for _, call := range a.calls {
-		res[call.Name], _ = a.contractAbi.Unpack(call.Method, results[call.Name].ReturnData)
+		decoded, decodeErr := a.contractAbi.Unpack(call.Method, results[call.Name].ReturnData)
+		if decodeErr != nil && unpackErr == nil {
+			unpackErr = decodeErr
+		}
+		res[call.Name] = decoded
 	}
 	a.ClearCall()
-	return blockNumber, res, err
+	return blockNumber, res, unpackErr
+}
+
+// callAllowFailure is Call's tolerant-mode path: a reverted sub-call leaves
+// its entry in the result map nil instead of aborting the whole batch.
+func (a *contract) callAllowFailure(blockNumber *big.Int) (*big.Int, map[string][]interface{}, error) {
+	blockNumber, results, err := a.multiCaller.ExecuteAllowFailure(a.calls, blockNumber)
+	if err != nil {
+		a.ClearCall()
+		return blockNumber, nil, err
+	}
+
+	res := make(map[string][]interface{})
+	var unpackErr error
+	for _, call := range a.calls {
+		result := results[call.Name]
+		if !result.Success {
+			res[call.Name] = nil
+			continue
+		}
+		decoded, decodeErr := a.contractAbi.Unpack(call.Method, result.ReturnData)
+		if decodeErr != nil && unpackErr == nil {
+			unpackErr = decodeErr
+		}
+		res[call.Name] = decoded
+	}
+	a.ClearCall()
+	return blockNumber, res, unpackErr
+}
+
+// CallAllowFailure batches calls through Multicall3's aggregate3 (or
+// Multicall2's tryAggregate when only that version is deployed), so a
+// revert in one call - e.g. symbol() or decimals() on a non-conforming
+// token - doesn't take down the whole batch. Each CallResult reports
+// whether its call succeeded and, if so, its decoded return values.
+func (a *contract) CallAllowFailure(blockNumber *big.Int) (*big.Int, []CallResult, error) {
+	if a.err != nil {
+		err := a.err
+		a.ClearCall()
+		return nil, nil, err
+	}
+
+	blockNumber, results, err := a.multiCaller.ExecuteAllowFailure(a.calls, blockNumber)
+	if err != nil {
+		a.ClearCall()
+		return blockNumber, nil, err
+	}
+
+	registry := errorsRegistry(a.contractAbi)
+	callResults := make([]CallResult, 0, len(a.calls))
+	for _, call := range a.calls {
+		result := results[call.Name]
+		callResult := CallResult{
+			Name:       call.Name,
+			Success:    result.Success,
+			ReturnData: result.ReturnData,
+		}
+		if result.Success {
+			callResult.DecodedValues, callResult.Err = a.contractAbi.Unpack(call.Method, result.ReturnData)
+		} else {
+			callResult.RevertReason = decodeRevert(registry, result.ReturnData)
+		}
+		callResults = append(callResults, callResult)
+	}
+	a.ClearCall()
+	return blockNumber, callResults, nil
 }
 
 func (a *contract) ClearCall() {
 	a.calls = []core.Call{}
 }
 
-func parseNewMethod(signature string) Method {
+func parseNewMethod(signature string) (Method, error) {
 	methodPaths := strings.Split(signature, "(")
 	if len(methodPaths) <= 1 {
-		panic("Function is invalid format!")
+		return Method{}, fmt.Errorf("call: invalid function signature %q", signature)
 	}
 	methodName := strings.Replace(methodPaths[0], "function", "", 1)
 	methodName = strings.TrimSpace(methodName)
@@ -184,9 +393,81 @@ func parseNewMethod(signature string) Method {
 			InternalType: returnType,
 		})
 	}
+	return newMethod, nil
+}
+
+// methodFromABI converts an abi.Method parsed from a JSON ABI into the
+// Method shape used internally by repackAbi, preserving the full argument
+// types (including tuples and arrays) that the signature-string parser
+// cannot represent.
+func methodFromABI(method abi.Method) Method {
+	newMethod := Method{
+		Name:            method.Name,
+		Inputs:          argumentsFromABI(method.Inputs),
+		Outputs:         argumentsFromABI(method.Outputs),
+		Type:            "function",
+		StateMutability: method.StateMutability,
+	}
 	return newMethod
 }
 
+// errorFromABI converts an abi.Error parsed from a JSON ABI into the
+// Method shape used internally by repackAbi, so it round-trips back into
+// an abi.ABI with its selector preserved.
+func errorFromABI(customErr abi.Error) Method {
+	return Method{
+		Name:    customErr.Name,
+		Inputs:  argumentsFromABI(customErr.Inputs),
+		Outputs: make([]Argument, 0),
+		Type:    "error",
+	}
+}
+
+// parseNewError parses a human-readable custom error signature, e.g.
+// "error InsufficientBalance(uint256 available, uint256 required)".
+func parseNewError(signature string) (Method, error) {
+	signature = strings.TrimSpace(strings.Replace(signature, "error", "", 1))
+	parts := strings.SplitN(signature, "(", 2)
+	if len(parts) <= 1 {
+		return Method{}, fmt.Errorf("call: invalid error signature %q", signature)
+	}
+	name := strings.TrimSpace(parts[0])
+	paramsPath := strings.TrimSuffix(parts[1], ")")
+
+	newMethod := Method{
+		Name:    name,
+		Inputs:  make([]Argument, 0),
+		Outputs: make([]Argument, 0),
+		Type:    "error",
+	}
+	for _, param := range parseParamsPath(paramsPath) {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+		fields := strings.Fields(param)
+		paramType := fields[0]
+		newMethod.Inputs = append(newMethod.Inputs, Argument{
+			Name:         "",
+			Type:         paramType,
+			InternalType: paramType,
+		})
+	}
+	return newMethod, nil
+}
+
+func argumentsFromABI(args abi.Arguments) []Argument {
+	arguments := make([]Argument, 0, len(args))
+	for _, arg := range args {
+		arguments = append(arguments, Argument{
+			Name:         arg.Name,
+			Type:         arg.Type.String(),
+			InternalType: arg.Type.String(),
+		})
+	}
+	return arguments
+}
+
 func parseParamsPath(paramsPath string) []string {
 	params := strings.Split(paramsPath, ",")
 	return params
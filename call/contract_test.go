@@ -0,0 +1,48 @@
+package call
+
+import "testing"
+
+func TestParseNewMethod(t *testing.T) {
+	method, err := parseNewMethod("function balanceOf(address)(uint256)")
+	if err != nil {
+		t.Fatalf("parseNewMethod: %v", err)
+	}
+	if method.Name != "balanceOf" {
+		t.Fatalf("Name = %q, want %q", method.Name, "balanceOf")
+	}
+	if len(method.Inputs) != 1 || method.Inputs[0].Type != "address" {
+		t.Fatalf("Inputs = %+v, want a single address input", method.Inputs)
+	}
+	if len(method.Outputs) != 1 || method.Outputs[0].Type != "uint256" {
+		t.Fatalf("Outputs = %+v, want a single uint256 output", method.Outputs)
+	}
+}
+
+func TestParseNewMethodMultipleReturn(t *testing.T) {
+	method, err := parseNewMethod("function getReserves()(uint112, uint112, uint32)")
+	if err != nil {
+		t.Fatalf("parseNewMethod: %v", err)
+	}
+	if len(method.Inputs) != 0 {
+		t.Fatalf("Inputs = %+v, want none", method.Inputs)
+	}
+	if len(method.Outputs) != 3 {
+		t.Fatalf("got %d outputs, want 3", len(method.Outputs))
+	}
+}
+
+func TestParseNewError(t *testing.T) {
+	method, err := parseNewError("error InsufficientBalance(uint256 available, uint256 required)")
+	if err != nil {
+		t.Fatalf("parseNewError: %v", err)
+	}
+	if method.Name != "InsufficientBalance" {
+		t.Fatalf("Name = %q, want %q", method.Name, "InsufficientBalance")
+	}
+	if method.Type != "error" {
+		t.Fatalf("Type = %q, want %q", method.Type, "error")
+	}
+	if len(method.Inputs) != 2 || method.Inputs[0].Type != "uint256" || method.Inputs[1].Type != "uint256" {
+		t.Fatalf("Inputs = %+v, want two uint256 inputs", method.Inputs)
+	}
+}
@@ -0,0 +1,325 @@
+package call
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var errTooManyResults = errors.New("call: query range too small to satisfy node result limit")
+
+// DefaultMaxBlockRange caps how many blocks a single eth_getLogs call
+// covers before EventBuilder splits the request into smaller chunks.
+const DefaultMaxBlockRange = 5000
+
+// DecodedLog is one decoded occurrence of an event.
+type DecodedLog struct {
+	BlockNumber uint64
+	TxHash      common.Hash
+	Indexed     map[string]interface{}
+	NonIndexed  map[string]interface{}
+}
+
+type EventBuilder interface {
+	WithClient(ethClient *ethclient.Client) EventBuilder
+	AtAddress(contractAddress string) EventBuilder
+	AddEvent(signature string) EventBuilder
+	WithABI(jsonABI string) EventBuilder
+	MaxRange(blocks uint64) EventBuilder
+	FetchLogs(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) (map[string][]DecodedLog, error)
+}
+
+type eventSet struct {
+	ethClient       *ethclient.Client
+	contractAddress common.Address
+	eventsAbi       abi.ABI
+	events          []string
+	maxRange        uint64
+	err             error
+}
+
+func NewEventBuilder() EventBuilder {
+	return &eventSet{
+		events:   make([]string, 0),
+		maxRange: DefaultMaxBlockRange,
+	}
+}
+
+func (e *eventSet) WithClient(ethClient *ethclient.Client) EventBuilder {
+	e.ethClient = ethClient
+	return e
+}
+
+func (e *eventSet) AtAddress(contractAddress string) EventBuilder {
+	e.contractAddress = common.HexToAddress(contractAddress)
+	return e
+}
+
+// AddEvent registers a human-readable event signature, e.g.
+// "event Transfer(address indexed from, address indexed to, uint256 value)".
+func (e *eventSet) AddEvent(signature string) EventBuilder {
+	if e.err != nil {
+		return e
+	}
+	method, err := parseNewEvent(signature)
+	if err != nil {
+		e.err = err
+		return e
+	}
+	e.events = append(e.events, method.Name)
+	abiJSON, err := json.Marshal([]Method{method})
+	if err != nil {
+		e.err = err
+		return e
+	}
+	return e.mergeABI(string(abiJSON))
+}
+
+// WithABI registers every event declared in a standard Solidity JSON ABI.
+func (e *eventSet) WithABI(jsonABI string) EventBuilder {
+	if e.err != nil {
+		return e
+	}
+	parsedAbi, err := abi.JSON(strings.NewReader(jsonABI))
+	if err != nil {
+		e.err = err
+		return e
+	}
+	for name := range parsedAbi.Events {
+		e.events = append(e.events, name)
+	}
+	return e.mergeABI(jsonABI)
+}
+
+func (e *eventSet) mergeABI(jsonABI string) EventBuilder {
+	if e.err != nil {
+		return e
+	}
+	parsedAbi, err := abi.JSON(strings.NewReader(jsonABI))
+	if err != nil {
+		e.err = err
+		return e
+	}
+	if len(e.eventsAbi.Events) == 0 {
+		e.eventsAbi = parsedAbi
+		return e
+	}
+	for name, event := range parsedAbi.Events {
+		e.eventsAbi.Events[name] = event
+	}
+	return e
+}
+
+// MaxRange overrides DefaultMaxBlockRange, the largest block span queried
+// through a single eth_getLogs call before chunking kicks in.
+func (e *eventSet) MaxRange(blocks uint64) EventBuilder {
+	e.maxRange = blocks
+	return e
+}
+
+// FetchLogs filters every registered event over [fromBlock, toBlock] with a
+// single eth_getLogs round-trip per block chunk, splitting the range when
+// the node reports too many results and decoding both indexed and
+// non-indexed arguments of each matching log.
+func (e *eventSet) FetchLogs(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) (map[string][]DecodedLog, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	topics := make([]common.Hash, 0, len(e.events))
+	for _, name := range e.events {
+		topics = append(topics, e.eventsAbi.Events[name].ID)
+	}
+
+	results := make(map[string][]DecodedLog)
+	chunks := chunkRange(fromBlock, toBlock, e.maxRange)
+	for _, chunk := range chunks {
+		logs, err := e.filterLogsWithBackoff(ctx, chunk.from, chunk.to, topics)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range logs {
+			event, ok := e.eventByTopic(log.Topics[0])
+			if !ok {
+				continue
+			}
+			decoded, err := decodeLog(event, log)
+			if err != nil {
+				return nil, err
+			}
+			results[event.Name] = append(results[event.Name], decoded)
+		}
+	}
+	return results, nil
+}
+
+func (e *eventSet) eventByTopic(topic common.Hash) (abi.Event, bool) {
+	for _, name := range e.events {
+		event := e.eventsAbi.Events[name]
+		if event.ID == topic {
+			return event, true
+		}
+	}
+	return abi.Event{}, false
+}
+
+// filterLogsWithBackoff splits the requested range in half and recurses
+// into both halves - concatenating their logs - when the node rejects the
+// query for returning too many results, a common limit on public RPC
+// endpoints. A single-block range that still won't fit is retried in place
+// with exponential backoff instead of being split further.
+func (e *eventSet) filterLogsWithBackoff(ctx context.Context, fromBlock, toBlock *big.Int, topics []common.Hash) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{e.contractAddress},
+		Topics:    [][]common.Hash{topics},
+	}
+
+	backoff := 200 * time.Millisecond
+	for attempt := 0; attempt < 5; attempt++ {
+		logs, err := e.ethClient.FilterLogs(ctx, query)
+		if err == nil {
+			return logs, nil
+		}
+		if !isTooManyResults(err) {
+			return nil, err
+		}
+		if fromBlock != nil && toBlock != nil && toBlock.Cmp(fromBlock) > 0 {
+			mid := new(big.Int).Add(fromBlock, toBlock)
+			mid.Div(mid, big.NewInt(2))
+			lower, err := e.filterLogsWithBackoff(ctx, fromBlock, mid, topics)
+			if err != nil {
+				return nil, err
+			}
+			upper, err := e.filterLogsWithBackoff(ctx, new(big.Int).Add(mid, big.NewInt(1)), toBlock, topics)
+			if err != nil {
+				return nil, err
+			}
+			return append(lower, upper...), nil
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return nil, errTooManyResults
+}
+
+func isTooManyResults(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "query returned more than")
+}
+
+// decodeLog splits a log's arguments into indexed (read from Topics) and
+// non-indexed (read from Data) values. Indexed dynamic types (string,
+// bytes, arrays) can only be recovered as their keccak hash, since that is
+// all the EVM stores in a topic slot.
+func decodeLog(event abi.Event, log types.Log) (DecodedLog, error) {
+	nonIndexed := make(map[string]interface{})
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(nonIndexed, log.Data); err != nil {
+		return DecodedLog{}, err
+	}
+
+	indexed := make(map[string]interface{})
+	topicIndex := 1
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		if topicIndex >= len(log.Topics) {
+			break
+		}
+		topic := log.Topics[topicIndex]
+		if input.Type.T == abi.StringTy || input.Type.T == abi.BytesTy || input.Type.T == abi.SliceTy || input.Type.T == abi.ArrayTy {
+			indexed[input.Name] = topic
+		} else {
+			args := abi.Arguments{abi.Argument{Name: input.Name, Type: input.Type, Indexed: false}}
+			unpacked, err := args.Unpack(topic.Bytes())
+			if err != nil {
+				return DecodedLog{}, err
+			}
+			indexed[input.Name] = unpacked[0]
+		}
+		topicIndex++
+	}
+
+	return DecodedLog{
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		Indexed:     indexed,
+		NonIndexed:  nonIndexed,
+	}, nil
+}
+
+type blockRange struct {
+	from *big.Int
+	to   *big.Int
+}
+
+func chunkRange(fromBlock, toBlock *big.Int, maxRange uint64) []blockRange {
+	if maxRange == 0 {
+		return []blockRange{{from: fromBlock, to: toBlock}}
+	}
+	span := new(big.Int).SetUint64(maxRange)
+	chunks := make([]blockRange, 0)
+	cursor := new(big.Int).Set(fromBlock)
+	for cursor.Cmp(toBlock) <= 0 {
+		end := new(big.Int).Add(cursor, span)
+		end.Sub(end, big.NewInt(1))
+		if end.Cmp(toBlock) > 0 {
+			end = new(big.Int).Set(toBlock)
+		}
+		chunks = append(chunks, blockRange{from: new(big.Int).Set(cursor), to: end})
+		cursor = new(big.Int).Add(end, big.NewInt(1))
+	}
+	return chunks
+}
+
+// parseNewEvent parses a human-readable event signature, reusing the same
+// "function"-style grammar as parseNewMethod but for the event keyword and
+// its "indexed" qualifier.
+func parseNewEvent(signature string) (Method, error) {
+	signature = strings.TrimSpace(strings.Replace(signature, "event", "", 1))
+	parts := strings.SplitN(signature, "(", 2)
+	if len(parts) <= 1 {
+		return Method{}, fmt.Errorf("call: invalid event signature %q", signature)
+	}
+	name := strings.TrimSpace(parts[0])
+	paramsPath := strings.TrimSuffix(parts[1], ")")
+
+	newMethod := Method{
+		Name:    name,
+		Inputs:  make([]Argument, 0),
+		Outputs: make([]Argument, 0),
+		Type:    "event",
+	}
+	for _, param := range parseParamsPath(paramsPath) {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+		indexed := false
+		if strings.Contains(param, "indexed") {
+			indexed = true
+			param = strings.Replace(param, "indexed", "", 1)
+		}
+		fields := strings.Fields(param)
+		paramType := fields[0]
+		paramName := fields[len(fields)-1]
+		newMethod.Inputs = append(newMethod.Inputs, Argument{
+			Name:         paramName,
+			Type:         paramType,
+			InternalType: paramType,
+			Indexed:      indexed,
+		})
+	}
+	return newMethod, nil
+}
@@ -0,0 +1,81 @@
+package call
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParseNewEvent(t *testing.T) {
+	method, err := parseNewEvent("event Transfer(address indexed from, address indexed to, uint256 value)")
+	if err != nil {
+		t.Fatalf("parseNewEvent: %v", err)
+	}
+	if method.Name != "Transfer" {
+		t.Fatalf("Name = %q, want %q", method.Name, "Transfer")
+	}
+
+	want := []struct {
+		name    string
+		typ     string
+		indexed bool
+	}{
+		{"from", "address", true},
+		{"to", "address", true},
+		{"value", "uint256", false},
+	}
+	if len(method.Inputs) != len(want) {
+		t.Fatalf("got %d inputs, want %d", len(method.Inputs), len(want))
+	}
+	for i, w := range want {
+		in := method.Inputs[i]
+		if in.Name != w.name || in.Type != w.typ || in.Indexed != w.indexed {
+			t.Errorf("input %d = %+v, want name=%s type=%s indexed=%v", i, in, w.name, w.typ, w.indexed)
+		}
+	}
+}
+
+func TestDecodeLogMultiIndexed(t *testing.T) {
+	e := &eventSet{}
+	e.AddEvent("event Transfer(address indexed from, address indexed to, uint256 value)")
+	if e.err != nil {
+		t.Fatalf("AddEvent: %v", e.err)
+	}
+	event := e.eventsAbi.Events["Transfer"]
+
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	value := big.NewInt(42)
+
+	data, err := abi.Arguments{event.Inputs[2]}.Pack(value)
+	if err != nil {
+		t.Fatalf("packing non-indexed data: %v", err)
+	}
+
+	log := types.Log{
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: data,
+	}
+
+	decoded, err := decodeLog(event, log)
+	if err != nil {
+		t.Fatalf("decodeLog: %v", err)
+	}
+	if got, ok := decoded.Indexed["from"].(common.Address); !ok || got != from {
+		t.Errorf("Indexed[from] = %v, want %v", decoded.Indexed["from"], from)
+	}
+	if got, ok := decoded.Indexed["to"].(common.Address); !ok || got != to {
+		t.Errorf("Indexed[to] = %v, want %v", decoded.Indexed["to"], to)
+	}
+	got, ok := decoded.NonIndexed["value"].(*big.Int)
+	if !ok || got.Cmp(value) != 0 {
+		t.Errorf("NonIndexed[value] = %v, want %v", decoded.NonIndexed["value"], value)
+	}
+}
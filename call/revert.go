@@ -0,0 +1,91 @@
+package call
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// standardErrorSelector and standardPanicSelector are the selectors Solidity
+// emits for its built-in revert reasons, require(cond, "reason") and
+// assert/overflow/division-by-zero respectively. They exist outside of any
+// ABI, so they're matched before falling back to a contract's own custom
+// errors.
+var (
+	standardErrorSelector = [4]byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	standardPanicSelector = [4]byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// panicReasons maps Solidity's builtin Panic(uint256) codes to the
+// human-readable condition the compiler documents for each one.
+var panicReasons = map[byte]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "storage byte array incorrectly encoded",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory or array too large",
+	0x51: "call to a zero-initialized variable of internal function type",
+}
+
+// decodeRevert turns the raw return data of a failed sub-call into a
+// human-readable reason, matching Solidity's builtin Error(string) and
+// Panic(uint256) first, then looking for a matching custom error in
+// registry.
+func decodeRevert(registry map[[4]byte]abi.Error, returnData []byte) string {
+	if len(returnData) < 4 {
+		return ""
+	}
+	var selector [4]byte
+	copy(selector[:], returnData[:4])
+
+	switch selector {
+	case standardErrorSelector:
+		unpacked, err := abi.UnpackRevert(returnData)
+		if err != nil {
+			return ""
+		}
+		return unpacked
+	case standardPanicSelector:
+		uintType, err := abi.NewType("uint256", "", nil)
+		if err != nil {
+			return "panic"
+		}
+		args, err := abi.Arguments{{Type: uintType}}.Unpack(returnData[4:])
+		if err != nil || len(args) == 0 {
+			return "panic"
+		}
+		code := args[0].(*big.Int).Uint64()
+		if reason, ok := panicReasons[byte(code)]; ok {
+			return fmt.Sprintf("panic: %s (code 0x%02x)", reason, code)
+		}
+		return fmt.Sprintf("panic: unknown code 0x%02x", code)
+	}
+
+	if customErr, ok := registry[selector]; ok {
+		args, err := customErr.Inputs.Unpack(returnData[4:])
+		if err != nil {
+			return customErr.Name
+		}
+		return fmt.Sprintf("%s%v", customErr.Name, args)
+	}
+
+	return fmt.Sprintf("unknown revert selector 0x%x", binary.BigEndian.Uint32(selector[:]))
+}
+
+// errorsRegistry builds a selector lookup table from every custom error
+// known to contractAbi, used to decode revert data from aggregate3 /
+// tryAggregate failures.
+func errorsRegistry(contractAbi abi.ABI) map[[4]byte]abi.Error {
+	registry := make(map[[4]byte]abi.Error, len(contractAbi.Errors))
+	for _, customErr := range contractAbi.Errors {
+		var selector [4]byte
+		copy(selector[:], customErr.ID.Bytes()[:4])
+		registry[selector] = customErr
+	}
+	return registry
+}
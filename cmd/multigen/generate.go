@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/depocket/multicall-go/call"
+)
+
+// methodModel is one view/pure function with a single return value - the
+// subset multigen can turn into a typed field on the generated results
+// struct. Everything else (mutating functions, multi-value returns,
+// events, errors) gets no generated method or result field, though it
+// stays in the ABI JSON embedded in the generated file.
+type methodModel struct {
+	GoName       string
+	Name         string
+	Inputs       []argumentModel
+	ResultGoType string
+}
+
+type argumentModel struct {
+	Name   string
+	GoType string
+}
+
+type fileModel struct {
+	Package  string
+	TypeName string
+	ABIJSON  string
+	Methods  []methodModel
+}
+
+// buildFileModel parses a Solidity JSON ABI and keeps only the
+// single-output view/pure functions, which is what Execute can decode
+// into a typed map without needing per-tuple struct generation.
+func buildFileModel(jsonABI, pkg, typeName string) (fileModel, []string, error) {
+	var methods []call.Method
+	if err := json.Unmarshal([]byte(jsonABI), &methods); err != nil {
+		return fileModel{}, nil, fmt.Errorf("multigen: parsing ABI: %w", err)
+	}
+
+	// abi.JSON (used by WithABI at runtime, see chunk0-1) disambiguates
+	// overloaded function names into its Methods map in JSON-array order,
+	// leaving the first occurrence of a name untouched and suffixing every
+	// later one with an incrementing index. abiName mirrors that exact
+	// scheme across every function in the ABI - not just the ones multigen
+	// keeps - so the generated AddCall(name, ..., "{{.Name}}", ...) call
+	// packs against the same method abi.JSON actually registered.
+	used := make(map[string]bool)
+	abiNames := make([]string, len(methods))
+	for i, method := range methods {
+		if method.Type != "function" {
+			continue
+		}
+		abiNames[i] = overloadedABIName(method.Name, used)
+	}
+
+	model := fileModel{Package: pkg, TypeName: typeName, ABIJSON: jsonABI}
+	var skipped []string
+	var candidates []call.Method
+	var candidateAbiNames []string
+	for i, method := range methods {
+		if method.Type != "function" {
+			continue
+		}
+		if method.StateMutability != "view" && method.StateMutability != "pure" {
+			skipped = append(skipped, fmt.Sprintf("%s: not a view/pure function", method.Name))
+			continue
+		}
+		if len(method.Outputs) != 1 {
+			skipped = append(skipped, fmt.Sprintf("%s: multigen only generates single-return functions", method.Name))
+			continue
+		}
+		candidates = append(candidates, method)
+		candidateAbiNames = append(candidateAbiNames, abiNames[i])
+	}
+
+	// Overloaded functions (distinct signatures, same name - possible since
+	// chunk0-1 added full ABI support) would otherwise all map to the same
+	// GoName and fail to compile, so number every name with more than one
+	// candidate, go-ethereum abigen style.
+	nameCount := make(map[string]int)
+	for _, method := range candidates {
+		nameCount[method.Name]++
+	}
+	nameSeen := make(map[string]int)
+
+	for i, method := range candidates {
+		goName := exportedName(method.Name)
+		if nameCount[method.Name] > 1 {
+			goName = fmt.Sprintf("%s%d", goName, nameSeen[method.Name])
+			nameSeen[method.Name]++
+		}
+
+		inputs := make([]argumentModel, 0, len(method.Inputs))
+		for j, input := range method.Inputs {
+			name := input.Name
+			if name == "" {
+				name = fmt.Sprintf("arg%d", j)
+			}
+			inputs = append(inputs, argumentModel{Name: name, GoType: goType(input.Type)})
+		}
+
+		model.Methods = append(model.Methods, methodModel{
+			GoName:       goName,
+			Name:         candidateAbiNames[i],
+			Inputs:       inputs,
+			ResultGoType: goType(method.Outputs[0].Type),
+		})
+	}
+	return model, skipped, nil
+}
+
+// overloadedABIName reproduces go-ethereum's abi.ABI.overloadedName: the
+// first occurrence of rawName is kept as-is, every later occurrence is
+// suffixed with an incrementing index starting at 0.
+func overloadedABIName(rawName string, used map[string]bool) string {
+	name := rawName
+	for idx := 0; used[name]; idx++ {
+		name = fmt.Sprintf("%s%d", rawName, idx)
+	}
+	used[name] = true
+	return name
+}
+
+func exportedName(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+var fileTemplate = template.Must(template.New("multigen").Parse(`// Code generated by multigen from a Solidity ABI. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/depocket/multicall-go/call"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const {{.TypeName}}ABI = ` + "`{{.ABIJSON}}`" + `
+
+// {{.TypeName}}MultiBatch batches read-only calls against a {{.TypeName}}
+// contract through multicall-go, decoding each call's return value into
+// {{.TypeName}}Results.
+type {{.TypeName}}MultiBatch struct {
+	builder call.ContractBuilder
+	address string
+	calls   map[string][]string
+}
+
+// New{{.TypeName}}MultiBatch builds a batch against the {{.TypeName}} contract
+// deployed at address.
+func New{{.TypeName}}MultiBatch(client *ethclient.Client, address string) (*{{.TypeName}}MultiBatch, error) {
+	builder, err := call.NewContractBuilder().
+		WithClient(client).
+		WithABI({{.TypeName}}ABI).
+		AtAddress(address).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return &{{.TypeName}}MultiBatch{builder: builder, address: address, calls: make(map[string][]string)}, nil
+}
+{{range .Methods}}
+// {{.GoName}} queues a {{.Name}} call named name against the contract.
+func (b *{{$.TypeName}}MultiBatch) {{.GoName}}(name string{{range .Inputs}}, {{.Name}} {{.GoType}}{{end}}) *{{$.TypeName}}MultiBatch {
+	b.builder = b.builder.AddCall(name, b.address, "{{.Name}}"{{range .Inputs}}, {{.Name}}{{end}})
+	b.calls["{{.GoName}}"] = append(b.calls["{{.GoName}}"], name)
+	return b
+}
+{{end}}
+// {{.TypeName}}Results holds the decoded return value of every queued call,
+// keyed by the name passed to the corresponding method above.
+type {{.TypeName}}Results struct {
+{{range .Methods}}	{{.GoName}} map[string]{{.ResultGoType}}
+{{end}}}
+
+// Execute runs every queued call through CallWithContext, which splits
+// large batches into sub-batches, all pinned to blockNumber (nil for the
+// latest block).
+func (b *{{.TypeName}}MultiBatch) Execute(ctx context.Context, blockNumber *big.Int) ({{.TypeName}}Results, error) {
+	results := {{.TypeName}}Results{
+{{range .Methods}}		{{.GoName}}: make(map[string]{{.ResultGoType}}),
+{{end}}	}
+
+	_, raw, err := b.builder.CallWithContext(ctx, blockNumber)
+	if err != nil {
+		return results, err
+	}
+
+{{range .Methods}}	for _, name := range b.calls["{{.GoName}}"] {
+		if values, ok := raw[name]; ok && len(values) > 0 {
+			if decoded, ok := values[0].({{.ResultGoType}}); ok {
+				results.{{.GoName}}[name] = decoded
+			}
+		}
+	}
+{{end}}	return results, nil
+}
+`))
+
+func render(model fileModel) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, model); err != nil {
+		return nil, fmt.Errorf("multigen: rendering template: %w", err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("multigen: formatting generated source: %w (%s)", err, strings.TrimSpace(buf.String()))
+	}
+	return formatted, nil
+}
@@ -0,0 +1,53 @@
+// Command multigen generates a strongly-typed multicall-go wrapper from a
+// Solidity JSON ABI, the same way go-ethereum's abigen generates bindings
+// from the same input. For each single-return view/pure function it emits
+// a chained builder method and an Execute that returns a struct of typed
+// maps instead of the map[string][]interface{} callers otherwise have to
+// type-assert by hand.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	abiPath := flag.String("abi", "", "path to the Solidity JSON ABI file (required)")
+	typeName := flag.String("type", "", "exported type name for the generated wrapper, e.g. ERC20 (required)")
+	pkg := flag.String("pkg", "main", "package name for the generated file")
+	out := flag.String("out", "", "output file path (required)")
+	flag.Parse()
+
+	if *abiPath == "" || *typeName == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*abiPath, *typeName, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(abiPath, typeName, pkg, out string) error {
+	abiJSON, err := os.ReadFile(abiPath)
+	if err != nil {
+		return fmt.Errorf("multigen: reading ABI file: %w", err)
+	}
+
+	model, skipped, err := buildFileModel(string(abiJSON), pkg, typeName)
+	if err != nil {
+		return err
+	}
+	for _, reason := range skipped {
+		fmt.Fprintf(os.Stderr, "multigen: skipping %s\n", reason)
+	}
+
+	source, err := render(model)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, source, 0o644)
+}
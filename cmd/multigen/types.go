@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var fixedBitsType = regexp.MustCompile(`^(u?int)(\d+)$`)
+var fixedBytesType = regexp.MustCompile(`^bytes(\d+)$`)
+
+// goType maps a Solidity type string to the Go type multicall-go's abi
+// decoder produces for it. This mirrors the subset of go-ethereum's
+// abi.Type -> reflect.Type mapping that view functions typically need;
+// tuples fall back to interface{} since generating a named struct per
+// tuple is out of scope for this first cut of the generator.
+func goType(solidityType string) string {
+	switch {
+	case solidityType == "address":
+		return "common.Address"
+	case solidityType == "bool":
+		return "bool"
+	case solidityType == "string":
+		return "string"
+	case solidityType == "bytes":
+		return "[]byte"
+	case fixedBytesType.MatchString(solidityType):
+		// abi.Unpack decodes bytesN into a fixed-size array, not a slice.
+		n := fixedBytesType.FindStringSubmatch(solidityType)[1]
+		return fmt.Sprintf("[%s]byte", n)
+	case strings.HasSuffix(solidityType, "[]"):
+		elem := goType(strings.TrimSuffix(solidityType, "[]"))
+		if elem == "interface{}" {
+			// a slice of tuples decodes into a slice of anonymous structs,
+			// which a []interface{} assertion never matches.
+			return "interface{}"
+		}
+		return "[]" + elem
+	case fixedBitsType.MatchString(solidityType):
+		return fixedIntGoType(solidityType)
+	case solidityType == "uint" || solidityType == "int":
+		return "*big.Int"
+	default:
+		// tuples and anything else multigen doesn't special-case yet.
+		return "interface{}"
+	}
+}
+
+func fixedIntGoType(solidityType string) string {
+	matches := fixedBitsType.FindStringSubmatch(solidityType)
+	prefix, bits := matches[1], matches[2]
+	switch bits {
+	case "8", "16", "32", "64":
+		return prefix + bits
+	default:
+		return "*big.Int"
+	}
+}
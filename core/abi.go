@@ -0,0 +1,91 @@
+package core
+
+// multicallABI covers the subset of the Multicall2/Multicall3 interface
+// this package drives: the legacy all-or-nothing aggregate, Multicall2's
+// tryAggregate, and Multicall3's aggregate3 plus getBasefee, which is used
+// purely as a cheap probe to tell the two deployments apart.
+const multicallABI = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "target", "type": "address"},
+					{"internalType": "bytes", "name": "callData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall2.Call[]",
+				"name": "calls",
+				"type": "tuple[]"
+			}
+		],
+		"name": "aggregate",
+		"outputs": [
+			{"internalType": "uint256", "name": "blockNumber", "type": "uint256"},
+			{"internalType": "bytes[]", "name": "returnData", "type": "bytes[]"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "bool", "name": "requireSuccess", "type": "bool"},
+			{
+				"components": [
+					{"internalType": "address", "name": "target", "type": "address"},
+					{"internalType": "bytes", "name": "callData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall2.Call[]",
+				"name": "calls",
+				"type": "tuple[]"
+			}
+		],
+		"name": "tryAggregate",
+		"outputs": [
+			{
+				"components": [
+					{"internalType": "bool", "name": "success", "type": "bool"},
+					{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall2.Result[]",
+				"name": "returnData",
+				"type": "tuple[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"components": [
+					{"internalType": "address", "name": "target", "type": "address"},
+					{"internalType": "bool", "name": "allowFailure", "type": "bool"},
+					{"internalType": "bytes", "name": "callData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall3.Call3[]",
+				"name": "calls",
+				"type": "tuple[]"
+			}
+		],
+		"name": "aggregate3",
+		"outputs": [
+			{
+				"components": [
+					{"internalType": "bool", "name": "success", "type": "bool"},
+					{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall3.Result[]",
+				"name": "returnData",
+				"type": "tuple[]"
+			}
+		],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getBasefee",
+		"outputs": [{"internalType": "uint256", "name": "basefee", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
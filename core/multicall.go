@@ -0,0 +1,213 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Call describes a single contract call to be batched through the
+// Multicall contract.
+type Call struct {
+	Name     string
+	Target   common.Address
+	Method   string
+	CallData []byte
+}
+
+// CallResult is the outcome of one Call within a batch. Success is always
+// true for batches executed through the legacy aggregate path, since that
+// path reverts the whole batch on any single failure.
+type CallResult struct {
+	Name       string
+	Success    bool
+	ReturnData []byte
+}
+
+// Version identifies which Multicall contract variant is deployed at the
+// target address.
+type Version int
+
+const (
+	Multicall2 Version = iota
+	Multicall3
+)
+
+type MultiCaller struct {
+	client          *ethclient.Client
+	contractAddress common.Address
+	contractAbi     abi.ABI
+	version         Version
+}
+
+func NewMultiCaller(client *ethclient.Client, contractAddress common.Address) (*MultiCaller, error) {
+	parsedAbi, err := abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		return nil, err
+	}
+	m := &MultiCaller{
+		client:          client,
+		contractAddress: contractAddress,
+		contractAbi:     parsedAbi,
+	}
+	m.version = m.detectVersion()
+	return m, nil
+}
+
+// detectVersion probes the deployed contract for getBasefee, which only
+// exists on Multicall3. Any failure to call it (missing method, revert) is
+// treated as "this is a Multicall2 deployment".
+func (m *MultiCaller) detectVersion() Version {
+	callData, err := m.contractAbi.Pack("getBasefee")
+	if err != nil {
+		return Multicall2
+	}
+	_, err = m.client.CallContract(context.Background(), ethereum.CallMsg{
+		To:   &m.contractAddress,
+		Data: callData,
+	}, nil)
+	if err != nil {
+		return Multicall2
+	}
+	return Multicall3
+}
+
+// Execute packages calls into the all-or-nothing aggregate function: a
+// single reverting call fails the whole batch.
+func (m *MultiCaller) Execute(calls []Call, blockNumber *big.Int) (*big.Int, map[string]CallResult, error) {
+	return m.ExecuteContext(context.Background(), calls, blockNumber)
+}
+
+// ExecuteContext is Execute with a caller-supplied context, so a batch can
+// be cancelled - e.g. on shutdown, or by one sub-batch of a larger split
+// failing - instead of always running to completion.
+func (m *MultiCaller) ExecuteContext(ctx context.Context, calls []Call, blockNumber *big.Int) (*big.Int, map[string]CallResult, error) {
+	type aggregateCall struct {
+		Target   common.Address
+		CallData []byte
+	}
+	packedCalls := make([]aggregateCall, len(calls))
+	for i, call := range calls {
+		packedCalls[i] = aggregateCall{Target: call.Target, CallData: call.CallData}
+	}
+
+	callData, err := m.contractAbi.Pack("aggregate", packedCalls)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawResult, err := m.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &m.contractAddress,
+		Data: callData,
+	}, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unpacked, err := m.contractAbi.Unpack("aggregate", rawResult)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultBlockNumber := unpacked[0].(*big.Int)
+	returnData := unpacked[1].([][]byte)
+
+	results := make(map[string]CallResult, len(calls))
+	for i, call := range calls {
+		results[call.Name] = CallResult{
+			Name:       call.Name,
+			Success:    true,
+			ReturnData: returnData[i],
+		}
+	}
+	return resultBlockNumber, results, nil
+}
+
+// ExecuteAllowFailure packages calls into Multicall3's aggregate3, falling
+// back to Multicall2's tryAggregate(false, calls) when the target address
+// only has a Multicall2 deployed. Unlike Execute, a reverting call is
+// reported in its own CallResult instead of failing the whole batch.
+func (m *MultiCaller) ExecuteAllowFailure(calls []Call, blockNumber *big.Int) (*big.Int, map[string]CallResult, error) {
+	return m.ExecuteAllowFailureContext(context.Background(), calls, blockNumber)
+}
+
+// ExecuteAllowFailureContext is ExecuteAllowFailure with a caller-supplied
+// context.
+func (m *MultiCaller) ExecuteAllowFailureContext(ctx context.Context, calls []Call, blockNumber *big.Int) (*big.Int, map[string]CallResult, error) {
+	var callData []byte
+	var err error
+
+	if m.version == Multicall3 {
+		type call3 struct {
+			Target       common.Address
+			AllowFailure bool
+			CallData     []byte
+		}
+		packedCalls := make([]call3, len(calls))
+		for i, call := range calls {
+			packedCalls[i] = call3{Target: call.Target, AllowFailure: true, CallData: call.CallData}
+		}
+		callData, err = m.contractAbi.Pack("aggregate3", packedCalls)
+	} else {
+		type call2 struct {
+			Target   common.Address
+			CallData []byte
+		}
+		packedCalls := make([]call2, len(calls))
+		for i, call := range calls {
+			packedCalls[i] = call2{Target: call.Target, CallData: call.CallData}
+		}
+		callData, err = m.contractAbi.Pack("tryAggregate", false, packedCalls)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawResult, err := m.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &m.contractAddress,
+		Data: callData,
+	}, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	method := "tryAggregate"
+	if m.version == Multicall3 {
+		method = "aggregate3"
+	}
+	unpacked, err := m.contractAbi.Unpack(method, rawResult)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+	rawResults := *abi.ConvertType(unpacked[0], new([]result)).(*[]result)
+
+	results := make(map[string]CallResult, len(calls))
+	for i, call := range calls {
+		results[call.Name] = CallResult{
+			Name:       call.Name,
+			Success:    rawResults[i].Success,
+			ReturnData: rawResults[i].ReturnData,
+		}
+	}
+
+	// aggregate3/tryAggregate don't return the block the batch executed
+	// against, so report the one the caller pinned the call to.
+	resultBlockNumber := blockNumber
+	if resultBlockNumber == nil {
+		latest, err := m.client.BlockNumber(ctx)
+		if err == nil {
+			resultBlockNumber = new(big.Int).SetUint64(latest)
+		}
+	}
+	return resultBlockNumber, results, nil
+}
@@ -0,0 +1,205 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// newFakeRPCServer stands in for a real node: handle decides the response
+// for every JSON-RPC call the ethclient makes, keyed by method name and raw
+// params, so MultiCaller's version probing and eth_call decoding can be
+// exercised without a live chain.
+func newFakeRPCServer(t *testing.T, handle func(method string, params []json.RawMessage) (interface{}, *rpcError)) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding rpc request: %v", err)
+		}
+		result, rpcErr := handle(req.Method, req.Params)
+		resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+		if rpcErr != nil {
+			resp.Error = rpcErr
+		} else {
+			resp.Result = result
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatalf("encoding rpc response: %v", err)
+		}
+	}))
+}
+
+// callData extracts the "data" field of an eth_call's first parameter, the
+// hex-encoded calldata the client is probing the node with.
+func callData(t *testing.T, rawParam json.RawMessage) []byte {
+	t.Helper()
+	var param struct {
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(rawParam, &param); err != nil {
+		t.Fatalf("decoding eth_call param: %v", err)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(param.Data, "0x"))
+	if err != nil {
+		t.Fatalf("decoding call data hex: %v", err)
+	}
+	return data
+}
+
+func hexResult(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func dialFakeClient(t *testing.T, server *httptest.Server) *ethclient.Client {
+	t.Helper()
+	client, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatalf("dialing fake server: %v", err)
+	}
+	return client
+}
+
+func TestDetectVersion(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		t.Fatalf("parsing multicallABI: %v", err)
+	}
+	getBasefeeSelector := parsedABI.Methods["getBasefee"].ID
+
+	t.Run("multicall3 when getBasefee succeeds", func(t *testing.T) {
+		packed, err := parsedABI.Methods["getBasefee"].Outputs.Pack(big.NewInt(1))
+		if err != nil {
+			t.Fatalf("packing getBasefee result: %v", err)
+		}
+		server := newFakeRPCServer(t, func(method string, params []json.RawMessage) (interface{}, *rpcError) {
+			if method != "eth_call" {
+				return "0x", nil
+			}
+			data := callData(t, params[0])
+			if !bytes.Equal(data[:4], getBasefeeSelector) {
+				t.Fatalf("unexpected eth_call selector %x", data[:4])
+			}
+			return hexResult(packed), nil
+		})
+		defer server.Close()
+
+		mc, err := NewMultiCaller(dialFakeClient(t, server), common.HexToAddress("0x0000000000000000000000000000000000000001"))
+		if err != nil {
+			t.Fatalf("NewMultiCaller: %v", err)
+		}
+		if mc.version != Multicall3 {
+			t.Errorf("version = %v, want Multicall3", mc.version)
+		}
+	})
+
+	t.Run("multicall2 when getBasefee reverts", func(t *testing.T) {
+		server := newFakeRPCServer(t, func(method string, params []json.RawMessage) (interface{}, *rpcError) {
+			return nil, &rpcError{Code: 3, Message: "execution reverted"}
+		})
+		defer server.Close()
+
+		mc, err := NewMultiCaller(dialFakeClient(t, server), common.HexToAddress("0x0000000000000000000000000000000000000001"))
+		if err != nil {
+			t.Fatalf("NewMultiCaller: %v", err)
+		}
+		if mc.version != Multicall2 {
+			t.Errorf("version = %v, want Multicall2", mc.version)
+		}
+	})
+}
+
+func TestExecuteAllowFailureContext(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		t.Fatalf("parsing multicallABI: %v", err)
+	}
+	getBasefeeSelector := parsedABI.Methods["getBasefee"].ID
+	aggregate3Selector := parsedABI.Methods["aggregate3"].ID
+
+	getBasefeePacked, err := parsedABI.Methods["getBasefee"].Outputs.Pack(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("packing getBasefee result: %v", err)
+	}
+
+	type resultTuple struct {
+		Success    bool
+		ReturnData []byte
+	}
+	aggregate3Packed, err := parsedABI.Methods["aggregate3"].Outputs.Pack([]resultTuple{
+		{Success: true, ReturnData: []byte{0x2a}},
+		{Success: false, ReturnData: nil},
+	})
+	if err != nil {
+		t.Fatalf("packing aggregate3 result: %v", err)
+	}
+
+	server := newFakeRPCServer(t, func(method string, params []json.RawMessage) (interface{}, *rpcError) {
+		if method != "eth_call" {
+			return "0x", nil
+		}
+		data := callData(t, params[0])
+		switch {
+		case bytes.Equal(data[:4], getBasefeeSelector):
+			return hexResult(getBasefeePacked), nil
+		case bytes.Equal(data[:4], aggregate3Selector):
+			return hexResult(aggregate3Packed), nil
+		default:
+			t.Fatalf("unexpected eth_call selector %x", data[:4])
+			return nil, nil
+		}
+	})
+	defer server.Close()
+
+	mc, err := NewMultiCaller(dialFakeClient(t, server), common.HexToAddress("0x0000000000000000000000000000000000000001"))
+	if err != nil {
+		t.Fatalf("NewMultiCaller: %v", err)
+	}
+	if mc.version != Multicall3 {
+		t.Fatalf("version = %v, want Multicall3", mc.version)
+	}
+
+	calls := []Call{
+		{Name: "ok", Target: common.HexToAddress("0x0000000000000000000000000000000000000002"), CallData: []byte{0x01}},
+		{Name: "revert", Target: common.HexToAddress("0x0000000000000000000000000000000000000003"), CallData: []byte{0x02}},
+	}
+	_, results, err := mc.ExecuteAllowFailureContext(context.Background(), calls, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("ExecuteAllowFailureContext: %v", err)
+	}
+	if !results["ok"].Success || !bytes.Equal(results["ok"].ReturnData, []byte{0x2a}) {
+		t.Errorf("results[ok] = %+v, want Success=true ReturnData=0x2a", results["ok"])
+	}
+	if results["revert"].Success {
+		t.Errorf("results[revert].Success = true, want false")
+	}
+}